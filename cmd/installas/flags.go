@@ -0,0 +1,145 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// extractHTTPFlag reports whether -http is present in args and returns the
+// remaining arguments with it removed. It is handled separately from the
+// rest of the build flags, which are passed through to `go install`
+// verbatim, because -http controls installas itself rather than the build.
+func extractHTTPFlag(args []string) (useHTTP bool, rest []string) {
+	rest = make([]string, 0, len(args))
+	for _, a := range args {
+		if a == "-http" || a == "--http" {
+			useHTTP = true
+			continue
+		}
+		rest = append(rest, a)
+	}
+	return useHTTP, rest
+}
+
+// extractXFlags pulls repeated "-X pkg.Var=value" arguments out of args,
+// mirroring `go build`'s own -ldflags -X flag but as a top-level installas
+// flag so a single -X survives being merged into whatever -ldflags the
+// caller already passed through. It returns the collected pkg.Var=value
+// strings and the remaining arguments.
+func extractXFlags(args []string) (xflags, rest []string, err error) {
+	rest = make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if a != "-X" && a != "--X" {
+			rest = append(rest, a)
+			continue
+		}
+		i++
+		if i >= len(args) {
+			return nil, nil, fmt.Errorf("-X requires a pkg.Var=value argument")
+		}
+		xflags = append(xflags, args[i])
+	}
+	return xflags, rest, nil
+}
+
+// extractStampArgs pulls repeated "-stamp pkg.Var[=value]" arguments out of
+// args and returns them unresolved (installas.ResolveStamp needs the source
+// module's directory, which installas.Install resolves internally), along
+// with the remaining arguments.
+func extractStampArgs(args []string) (stampArgs, rest []string, err error) {
+	rest = make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if a != "-stamp" && a != "--stamp" {
+			rest = append(rest, a)
+			continue
+		}
+		i++
+		if i >= len(args) {
+			return nil, nil, fmt.Errorf("-stamp requires a pkg.Var[=value] argument")
+		}
+		stampArgs = append(stampArgs, args[i])
+	}
+	return stampArgs, rest, nil
+}
+
+// extractOFlag pulls a "-o dir" argument out of args, returning the
+// requested install directory (used to override GOBIN) and the remaining
+// arguments.
+func extractOFlag(args []string) (dir string, rest []string, err error) {
+	rest = make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if a != "-o" && a != "--o" {
+			rest = append(rest, a)
+			continue
+		}
+		i++
+		if i >= len(args) {
+			return "", nil, fmt.Errorf("-o requires a directory argument")
+		}
+		dir = args[i]
+	}
+	return dir, rest, nil
+}
+
+// extractTargetsFlag pulls a "-targets file" argument out of args, returning
+// the file path and the remaining arguments.
+func extractTargetsFlag(args []string) (file string, rest []string, err error) {
+	rest = make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if a != "-targets" && a != "--targets" {
+			rest = append(rest, a)
+			continue
+		}
+		i++
+		if i >= len(args) {
+			return "", nil, fmt.Errorf("-targets requires a file argument")
+		}
+		file = args[i]
+	}
+	return file, rest, nil
+}
+
+// splitTargets splits args into leading build flags and the trailing
+// target@version arguments. A "--" marks the boundary explicitly; without
+// one, args must contain no flags at all (so there's no build flag value,
+// e.g. an -ldflags value containing "@", to be misread as a target).
+func splitTargets(args []string) (buildFlags, targets []string, err error) {
+	for i, a := range args {
+		if a == "--" {
+			return args[:i], args[i+1:], nil
+		}
+	}
+	for _, a := range args {
+		if strings.HasPrefix(a, "-") {
+			return nil, nil, fmt.Errorf(`build flags and targets must be separated by "--" (e.g. installas -ldflags "..." -- ./cmd/foo@v1.2.3)`)
+		}
+	}
+	return nil, args, nil
+}
+
+// readTargetsFile reads newline-separated target@version entries from
+// path, ignoring blank lines and lines starting with #.
+func readTargetsFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading -targets file: %w", err)
+	}
+	var targets []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		targets = append(targets, line)
+	}
+	return targets, nil
+}
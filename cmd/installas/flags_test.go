@@ -0,0 +1,95 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitTargets(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		wantBuildFlags []string
+		wantTargets    []string
+		wantErr        bool
+	}{
+		{
+			name:        "bare targets need no separator",
+			args:        []string{"./cmd/foo@v1.2.3", "./cmd/bar@v2"},
+			wantTargets: []string{"./cmd/foo@v1.2.3", "./cmd/bar@v2"},
+		},
+		{
+			name:           "build flags require a -- separator",
+			args:           []string{"-ldflags", "-X main.email=a@b.com", "--", "./cmd/foo@v1.2.3"},
+			wantBuildFlags: []string{"-ldflags", "-X main.email=a@b.com"},
+			wantTargets:    []string{"./cmd/foo@v1.2.3"},
+		},
+		{
+			name:    "flags without -- are rejected, even a flag with no @ in it",
+			args:    []string{"-race", "./cmd/foo@v1.2.3"},
+			wantErr: true,
+		},
+		{
+			name:    "an @ in a flag value without -- is rejected, not misparsed as a target",
+			args:    []string{"-ldflags", "-X main.email=a@b.com", "./cmd/foo@v1.2.3"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buildFlags, targets, err := splitTargets(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("splitTargets(%v) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(buildFlags) != 0 || len(tt.wantBuildFlags) != 0 {
+				if !reflect.DeepEqual(buildFlags, tt.wantBuildFlags) {
+					t.Errorf("splitTargets(%v) buildFlags = %v, want %v", tt.args, buildFlags, tt.wantBuildFlags)
+				}
+			}
+			if !reflect.DeepEqual(targets, tt.wantTargets) {
+				t.Errorf("splitTargets(%v) targets = %v, want %v", tt.args, targets, tt.wantTargets)
+			}
+		})
+	}
+}
+
+func TestExtractXFlags(t *testing.T) {
+	xflags, rest, err := extractXFlags([]string{"-X", "main.version=v1.2.3", "./cmd/foo@v1"})
+	if err != nil {
+		t.Fatalf("extractXFlags returned error: %v", err)
+	}
+	if want := []string{"main.version=v1.2.3"}; !reflect.DeepEqual(xflags, want) {
+		t.Errorf("xflags = %v, want %v", xflags, want)
+	}
+	if want := []string{"./cmd/foo@v1"}; !reflect.DeepEqual(rest, want) {
+		t.Errorf("rest = %v, want %v", rest, want)
+	}
+
+	if _, _, err := extractXFlags([]string{"-X"}); err == nil {
+		t.Error("extractXFlags with a dangling -X: want error, got nil")
+	}
+}
+
+func TestExtractStampArgs(t *testing.T) {
+	stampArgs, rest, err := extractStampArgs([]string{"-stamp", "main.commit", "./cmd/foo@v1"})
+	if err != nil {
+		t.Fatalf("extractStampArgs returned error: %v", err)
+	}
+	if want := []string{"main.commit"}; !reflect.DeepEqual(stampArgs, want) {
+		t.Errorf("stampArgs = %v, want %v", stampArgs, want)
+	}
+	if want := []string{"./cmd/foo@v1"}; !reflect.DeepEqual(rest, want) {
+		t.Errorf("rest = %v, want %v", rest, want)
+	}
+
+	if _, _, err := extractStampArgs([]string{"-stamp"}); err == nil {
+		t.Error("extractStampArgs with a dangling -stamp: want error, got nil")
+	}
+}
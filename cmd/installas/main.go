@@ -0,0 +1,135 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Command installas builds and installs a go binary with a fake version
+// stamp. It is a workaround for go.dev/issues/50603.
+//
+// Usage:
+//
+//	go install github.com/hyangah/installas/cmd/installas@latest
+//	cd <your_project_main_module_directory>
+//	installas <path_to_your_tool>@<version>
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/hyangah/installas"
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s [build flags] [--] <target> [<target>...]\n", filepath.Base(os.Args[0]))
+	fmt.Fprintf(os.Stderr, " installs the target package(s) with the specified version(s).\n")
+	fmt.Fprintf(os.Stderr, " \"--\" must separate build flags from targets whenever build flags are\n")
+	fmt.Fprintf(os.Stderr, " given, so a flag value containing \"@\" (e.g. -ldflags \"-X a.b=x@y\") is\n")
+	fmt.Fprintf(os.Stderr, " never mistaken for a target.\n")
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, " target: package@version (./cmd/coolbin@v0.0.1) or @version (@v0.0.1)\n")
+	fmt.Fprintf(os.Stderr, "         version may also be @pseudo or @devel to synthesize a pseudo-version\n")
+	fmt.Fprintf(os.Stderr, "         from the current VCS state (requires a git checkout).\n")
+	fmt.Fprintf(os.Stderr, "         Multiple targets share a single synthetic GOPROXY and are installed\n")
+	fmt.Fprintf(os.Stderr, "         with one `go install` invocation.\n")
+	fmt.Fprintf(os.Stderr, "The binary will be install in the GOBIN or GOPATH/bin directory.\n")
+	fmt.Fprintf(os.Stderr, "If you want to install the binary in a different location, use GOBIN or -o.\n")
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, " -http: serve the synthetic module over a local HTTP proxy server\n")
+	fmt.Fprintf(os.Stderr, "        instead of a file:// GOPROXY entry.\n")
+	fmt.Fprintf(os.Stderr, " -o dir: install into dir instead of GOBIN/GOPATH/bin.\n")
+	fmt.Fprintf(os.Stderr, " -targets file: read additional newline-separated target@version\n")
+	fmt.Fprintf(os.Stderr, "        entries from file; blank lines and lines starting with # are\n")
+	fmt.Fprintf(os.Stderr, "        ignored.\n")
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, " -X pkg.Var=value: stamp pkg.Var with value via -ldflags, repeatable.\n")
+	fmt.Fprintf(os.Stderr, " -stamp pkg.Var[=value]: like -X, but pkg.Var may name a well-known\n")
+	fmt.Fprintf(os.Stderr, "        variable (revision or commit, time or buildtime, modified or\n")
+	fmt.Fprintf(os.Stderr, "        dirty) and be given without a\n")
+	fmt.Fprintf(os.Stderr, "        value, in which case the value is derived from the current VCS\n")
+	fmt.Fprintf(os.Stderr, "        state, e.g. -stamp main.commit.\n")
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+	useHTTP, rest := extractHTTPFlag(os.Args[1:])
+	xflags, rest, err := extractXFlags(rest)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		usage()
+		os.Exit(1)
+	}
+	stampArgs, rest, err := extractStampArgs(rest)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		usage()
+		os.Exit(1)
+	}
+	gobin, rest, err := extractOFlag(rest)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		usage()
+		os.Exit(1)
+	}
+	targetsFile, rest, err := extractTargetsFlag(rest)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		usage()
+		os.Exit(1)
+	}
+
+	buildFlags, targets, err := splitTargets(rest)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		usage()
+		os.Exit(1)
+	}
+	if targetsFile != "" {
+		fileTargets, err := readTargetsFile(targetsFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		targets = append(targets, fileTargets...)
+	}
+	if len(targets) == 0 {
+		usage()
+		os.Exit(1)
+	}
+
+	err = installas.Install(context.Background(), installas.Options{
+		Targets:    targets,
+		BuildFlags: buildFlags,
+		XFlags:     xflags,
+		StampArgs:  stampArgs,
+		UseHTTP:    useHTTP,
+		GoBin:      gobin,
+		Stdin:      os.Stdin,
+		Stdout:     os.Stdout,
+		Stderr:     os.Stderr,
+	})
+	if err != nil {
+		var instErr *installas.Error
+		if errors.As(err, &instErr) {
+			switch instErr.Kind {
+			case installas.KindGoInstallFailed:
+				var exitErr *exec.ExitError
+				if errors.As(instErr.Err, &exitErr) {
+					log.Println(err)
+					os.Exit(exitErr.ExitCode())
+				}
+			case installas.KindInvalidTarget, installas.KindGoListFailed:
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+		}
+		log.Panic(err)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package installas
+
+import "fmt"
+
+// ErrorKind classifies the stage of an Install call that failed, so
+// callers embedding this package can react differently to, say, a bad
+// target versus a failed build.
+type ErrorKind int
+
+const (
+	// KindInvalidTarget means the target@version argument itself was
+	// malformed, `go list` output for it could not be parsed, or no
+	// pseudo-version could be derived for it.
+	KindInvalidTarget ErrorKind = iota
+	// KindGoListFailed means the underlying `go list` invocation used to
+	// resolve the target's module failed.
+	KindGoListFailed
+	// KindGoInstallFailed means the underlying `go install` invocation
+	// failed.
+	KindGoInstallFailed
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case KindInvalidTarget:
+		return "invalid target"
+	case KindGoListFailed:
+		return "go list failed"
+	case KindGoInstallFailed:
+		return "go install failed"
+	default:
+		return "unknown error"
+	}
+}
+
+// Error wraps a failure from Install with the stage at which it occurred.
+type Error struct {
+	Kind ErrorKind
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %v", e.Kind, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
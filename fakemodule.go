@@ -0,0 +1,90 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package installas
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/zip"
+)
+
+func checkClose(name string, closer io.Closer, err *error) {
+	if cerr := closer.Close(); cerr != nil && *err == nil {
+		*err = fmt.Errorf("closing %s: %v", name, cerr)
+	}
+}
+
+// ProxyFileURL returns the file:// URL for a proxy directory populated by
+// WriteFakeModule, suitable for use as a GOPROXY entry.
+func ProxyFileURL(dir string) string {
+	// file URLs on Windows must start with file:///. See golang.org/issue/6027.
+	path := filepath.ToSlash(dir)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return "file://" + path
+}
+
+// WriteFakeModule stamps mod@ver into rootDir in the layout a GOPROXY
+// implementation serves (see https://go.dev/ref/mod#goproxy-protocol),
+// using sourceDir's go.mod and source files as the module's contents. It
+// may be called repeatedly against the same rootDir, including for
+// multiple versions of the same module, which appends to that module's
+// @v/list file.
+func WriteFakeModule(rootDir, mod, ver, sourceDir string) (rerr error) {
+	dir := filepath.Join(rootDir, mod, "@v")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(dir, "list"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	defer checkClose("list file", f, &rerr)
+	if _, err := f.WriteString(ver + "\n"); err != nil {
+		return err
+	}
+
+	// Serve the go.mod file on the <version>.mod url. A missing go.mod is an
+	// error.
+	modContents, err := os.ReadFile(filepath.Join(sourceDir, "go.mod"))
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, ver+".mod"), modContents, 0644); err != nil {
+		return err
+	}
+
+	infoContents := []byte(fmt.Sprintf(`{"Version": "%v", "Time":"%v"}`, ver, time.Now().UTC().Format(time.RFC3339)))
+	if err := os.WriteFile(filepath.Join(dir, ver+".info"), infoContents, 0644); err != nil {
+		return err
+	}
+
+	// zip of all the source files.
+	zipFile, err := os.OpenFile(filepath.Join(dir, ver+".zip"), os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer checkClose("zip file", zipFile, &rerr)
+	if err := zip.CreateFromDir(zipFile, module.Version{Path: mod, Version: ver}, sourceDir); err != nil {
+		return err
+	}
+
+	// Populate the /module/path/@latest that is used by @latest query.
+	if module.IsPseudoVersion(ver) {
+		latestFile := filepath.Join(rootDir, mod, "@latest")
+		if err := os.WriteFile(latestFile, infoContents, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package installas
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestSourceDir(t *testing.T, mod string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module "+mod+"\n\ngo 1.20\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestWriteFakeModuleAppendsToList(t *testing.T) {
+	const mod = "example.com/foo"
+	sourceDir := writeTestSourceDir(t, mod)
+	rootDir := t.TempDir()
+
+	if err := WriteFakeModule(rootDir, mod, "v1.0.0", sourceDir); err != nil {
+		t.Fatalf("WriteFakeModule(v1.0.0) failed: %v", err)
+	}
+	if err := WriteFakeModule(rootDir, mod, "v1.0.1", sourceDir); err != nil {
+		t.Fatalf("WriteFakeModule(v1.0.1) failed: %v", err)
+	}
+
+	list, err := os.ReadFile(filepath.Join(rootDir, mod, "@v", "list"))
+	if err != nil {
+		t.Fatalf("reading @v/list: %v", err)
+	}
+	got := strings.Fields(string(list))
+	want := []string{"v1.0.0", "v1.0.1"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("@v/list = %q, want lines %v", list, want)
+	}
+}
+
+func TestWriteFakeModuleLatestOnlyForPseudoVersion(t *testing.T) {
+	const mod = "example.com/foo"
+	sourceDir := writeTestSourceDir(t, mod)
+
+	rootDir := t.TempDir()
+	if err := WriteFakeModule(rootDir, mod, "v1.0.0", sourceDir); err != nil {
+		t.Fatalf("WriteFakeModule(v1.0.0) failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(rootDir, mod, "@latest")); !os.IsNotExist(err) {
+		t.Errorf("@latest exists for non-pseudo version v1.0.0, want it absent (err=%v)", err)
+	}
+
+	pseudoRootDir := t.TempDir()
+	const pseudoVer = "v1.0.1-0.20230101000000-abcdefabcdef"
+	if err := WriteFakeModule(pseudoRootDir, mod, pseudoVer, sourceDir); err != nil {
+		t.Fatalf("WriteFakeModule(%s) failed: %v", pseudoVer, err)
+	}
+	if _, err := os.Stat(filepath.Join(pseudoRootDir, mod, "@latest")); err != nil {
+		t.Errorf("@latest missing for pseudo version %s: %v", pseudoVer, err)
+	}
+}
+
+func TestWriteFakeModuleMissingGoMod(t *testing.T) {
+	sourceDir := t.TempDir() // no go.mod written
+	rootDir := t.TempDir()
+
+	if err := WriteFakeModule(rootDir, "example.com/foo", "v1.0.0", sourceDir); err == nil {
+		t.Fatal("WriteFakeModule with no go.mod in sourceDir succeeded, want error")
+	}
+}
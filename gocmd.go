@@ -0,0 +1,34 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package installas
+
+import (
+	"os/exec"
+	"path/filepath"
+	"runtime"
+)
+
+// GoCommand returns the path to the go command to run. If explicit is
+// non-empty, it is returned as-is. Otherwise, GOROOT/bin/go is preferred
+// over whatever "go" exec.LookPath finds on PATH, mirroring the toolchain's
+// own internal/execabs hardening: it keeps installas from accidentally
+// running a "go" binary planted in the current directory (notably on
+// Windows, where os/exec's relative-PATH protections don't cover every
+// shell) instead of the real toolchain.
+func GoCommand(explicit string) (string, error) {
+	if explicit != "" {
+		return explicit, nil
+	}
+	if goroot := runtime.GOROOT(); goroot != "" {
+		candidate := filepath.Join(goroot, "bin", "go")
+		if runtime.GOOS == "windows" {
+			candidate += ".exe"
+		}
+		if _, err := exec.LookPath(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+	return exec.LookPath("go")
+}
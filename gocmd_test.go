@@ -0,0 +1,28 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package installas
+
+import "testing"
+
+func TestGoCommandExplicit(t *testing.T) {
+	const explicit = "/custom/path/to/go"
+	got, err := GoCommand(explicit)
+	if err != nil {
+		t.Fatalf("GoCommand(%q) returned error: %v", explicit, err)
+	}
+	if got != explicit {
+		t.Errorf("GoCommand(%q) = %q, want it returned unchanged", explicit, got)
+	}
+}
+
+func TestGoCommandFallback(t *testing.T) {
+	got, err := GoCommand("")
+	if err != nil {
+		t.Skipf("no go command resolvable in this environment: %v", err)
+	}
+	if got == "" {
+		t.Error("GoCommand(\"\") returned an empty path with no error")
+	}
+}
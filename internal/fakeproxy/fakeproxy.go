@@ -0,0 +1,61 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package fakeproxy serves a directory populated in the layout expected by
+// the Go module proxy protocol (see https://go.dev/ref/mod#goproxy-protocol)
+// over HTTP. installas writes its synthetic, version-stamped modules to such
+// a directory already; this package lets that directory be reached via
+// GOPROXY=http://... instead of GOPROXY=file://..., for environments where
+// file:// proxies are blocked (some corporate networks, sandboxed CI), and
+// lets multiple concurrent `go install` invocations share one server.
+package fakeproxy
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ModuleVersion identifies a module@version pair that Serve expects to find
+// already written under dir, so it can fail fast with a clear error instead
+// of surfacing an opaque 404 from the go command later.
+type ModuleVersion struct {
+	Path    string
+	Version string
+}
+
+// Serve starts an HTTP server rooted at dir, which must already contain the
+// @v/list, @v/<version>.info, @v/<version>.mod, @v/<version>.zip (and, for
+// pseudo-versions, @latest) files that WriteFakeModule produces for each
+// entry in mods. It returns the server's base URL, suitable for use as a
+// GOPROXY entry, and a shutdown func that stops the server and releases its
+// listener. The caller is responsible for calling shutdown.
+func Serve(dir string, mods []ModuleVersion) (url string, shutdown func(), err error) {
+	for _, m := range mods {
+		verDir := filepath.Join(dir, m.Path, "@v")
+		for _, suffix := range []string{"list", m.Version + ".info", m.Version + ".mod", m.Version + ".zip"} {
+			if _, err := os.Stat(filepath.Join(verDir, suffix)); err != nil {
+				return "", nil, fmt.Errorf("fakeproxy: %s@%s: %w", m.Path, m.Version, err)
+			}
+		}
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", nil, fmt.Errorf("fakeproxy: listen: %w", err)
+	}
+	srv := &http.Server{Handler: http.FileServer(http.Dir(dir))}
+	go srv.Serve(ln)
+
+	shutdown = func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(ctx)
+	}
+	return "http://" + ln.Addr().String(), shutdown, nil
+}
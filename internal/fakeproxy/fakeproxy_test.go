@@ -0,0 +1,87 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fakeproxy
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeFixtureModule writes the files WriteFakeModule would produce for
+// mod@ver under dir, without depending on the installas package (which
+// imports this one).
+func writeFixtureModule(t *testing.T, dir, mod, ver string) {
+	t.Helper()
+	verDir := filepath.Join(dir, mod, "@v")
+	if err := os.MkdirAll(verDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"list":        ver + "\n",
+		ver + ".info": `{"Version": "` + ver + `", "Time":"2023-01-01T00:00:00Z"}`,
+		ver + ".mod":  "module " + mod + "\n\ngo 1.20\n",
+		ver + ".zip":  "not a real zip, Serve only stats this file",
+	}
+	for name, contents := range files {
+		if err := os.WriteFile(filepath.Join(verDir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestServe(t *testing.T) {
+	const mod, ver = "example.com/foo", "v1.0.0"
+	dir := t.TempDir()
+	writeFixtureModule(t, dir, mod, ver)
+
+	url, shutdown, err := Serve(dir, []ModuleVersion{{Path: mod, Version: ver}})
+	if err != nil {
+		t.Fatalf("Serve failed: %v", err)
+	}
+	defer shutdown()
+
+	resp, err := http.Get(url + "/" + mod + "/@v/list")
+	if err != nil {
+		t.Fatalf("GET @v/list: %v", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatalf("reading @v/list body: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET @v/list status = %d, want 200", resp.StatusCode)
+	}
+	if got, want := string(body), ver+"\n"; got != want {
+		t.Errorf("@v/list body = %q, want %q", got, want)
+	}
+
+	resp, err = http.Get(url + "/" + mod + "/@v/" + ver + ".info")
+	if err != nil {
+		t.Fatalf("GET %s.info: %v", ver, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("GET %s.info status = %d, want 200", ver, resp.StatusCode)
+	}
+}
+
+func TestServeMissingFileFailsFast(t *testing.T) {
+	const mod, ver = "example.com/foo", "v1.0.0"
+	dir := t.TempDir()
+	writeFixtureModule(t, dir, mod, ver)
+
+	// Remove a required file so the pre-flight stat loop should catch it.
+	if err := os.Remove(filepath.Join(dir, mod, "@v", ver+".zip")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := Serve(dir, []ModuleVersion{{Path: mod, Version: ver}}); err == nil {
+		t.Fatal("Serve with a missing required file succeeded, want error")
+	}
+}
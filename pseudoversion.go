@@ -0,0 +1,71 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package installas
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/module"
+)
+
+// ResolvePseudoVersion synthesizes a pseudo-version for the git repository
+// rooted at dir, following the same scheme `go install` uses to stamp
+// VCS-built binaries: it combines the nearest reachable release tag (if
+// any) with the HEAD commit's committer time and hash via
+// module.PseudoVersion. It lets callers write `installas ./cmd/foo@pseudo`
+// instead of hand-crafting the version string.
+func ResolvePseudoVersion(dir, modPath string) (string, error) {
+	commitTime, hash, err := headCommitInfo(dir)
+	if err != nil {
+		return "", fmt.Errorf("resolving pseudo-version: %w", err)
+	}
+	if len(hash) > 12 {
+		hash = hash[:12]
+	}
+	return module.PseudoVersion(moduleMajor(modPath), nearestTag(dir), commitTime, hash), nil
+}
+
+// headCommitInfo returns HEAD's committer time (in UTC) and full hash for
+// the git repository rooted at dir.
+func headCommitInfo(dir string) (time.Time, string, error) {
+	out, err := exec.Command("git", "-C", dir, "log", "-1", "--format=%cI %H").Output()
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("git log: %w", err)
+	}
+	fields := strings.Fields(strings.TrimSpace(string(out)))
+	if len(fields) != 2 {
+		return time.Time{}, "", fmt.Errorf("unexpected `git log` output: %q", out)
+	}
+	t, err := time.Parse(time.RFC3339, fields[0])
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("parsing commit time %q: %w", fields[0], err)
+	}
+	return t.UTC(), fields[1], nil
+}
+
+// nearestTag returns the nearest semver release tag reachable from HEAD in
+// the git repository rooted at dir, or "" if there is none.
+func nearestTag(dir string) string {
+	out, err := exec.Command("git", "-C", dir, "describe", "--tags", "--abbrev=0", "--match", "v[0-9]*").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// moduleMajor returns the major version, e.g. "v2", encoded in modPath's
+// import path suffix, or "v0" if modPath carries no major version suffix.
+func moduleMajor(modPath string) string {
+	_, pathMajor, ok := module.SplitPathVersion(modPath)
+	pathMajor = strings.TrimPrefix(pathMajor, "/")
+	pathMajor = strings.TrimPrefix(pathMajor, ".")
+	if !ok || pathMajor == "" {
+		return "v0"
+	}
+	return pathMajor
+}
@@ -0,0 +1,23 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package installas
+
+import "testing"
+
+func TestModuleMajor(t *testing.T) {
+	tests := []struct {
+		modPath string
+		want    string
+	}{
+		{"github.com/hyangah/installas", "v0"},
+		{"github.com/hyangah/installas/v3", "v3"},
+		{"gopkg.in/yaml.v2", "v2"},
+	}
+	for _, tt := range tests {
+		if got := moduleMajor(tt.modPath); got != tt.want {
+			t.Errorf("moduleMajor(%q) = %q, want %q", tt.modPath, got, tt.want)
+		}
+	}
+}
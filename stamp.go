@@ -0,0 +1,98 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package installas
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// ResolveStamp turns a "-stamp"-style argument into an "-X"-style
+// pkg.Var=value string. If arg already has a value, it's used as-is.
+// Otherwise pkg.Var's final segment must name a well-known variable
+// (revision or commit, time or buildtime, modified or dirty; case is
+// ignored), whose value is derived from the git repository rooted at dir.
+func ResolveStamp(arg, dir string) (string, error) {
+	key, value, hasValue := strings.Cut(arg, "=")
+	if hasValue {
+		return key + "=" + value, nil
+	}
+
+	name := key
+	if i := strings.LastIndex(key, "."); i >= 0 {
+		name = key[i+1:]
+	}
+	switch strings.ToLower(name) {
+	case "revision", "commit":
+		_, hash, err := headCommitInfo(dir)
+		if err != nil {
+			return "", err
+		}
+		return key + "=" + hash, nil
+	case "time", "buildtime":
+		t, _, err := headCommitInfo(dir)
+		if err != nil {
+			return "", err
+		}
+		return key + "=" + t.Format("2006-01-02T15:04:05Z07:00"), nil
+	case "modified", "dirty":
+		dirty, err := IsDirty(dir)
+		if err != nil {
+			return "", err
+		}
+		return key + "=" + strconv.FormatBool(dirty), nil
+	default:
+		return "", fmt.Errorf("-stamp %s: no value given and %q is not a well-known variable (want revision, time, or modified)", arg, name)
+	}
+}
+
+// IsDirty reports whether the git repository rooted at dir has uncommitted
+// changes.
+func IsDirty(dir string) (bool, error) {
+	out, err := exec.Command("git", "-C", dir, "status", "--porcelain").Output()
+	if err != nil {
+		return false, fmt.Errorf("git status: %w", err)
+	}
+	return len(strings.TrimSpace(string(out))) > 0, nil
+}
+
+// MergeLdflags appends "-X "-prefixed entries for each value in xflags to
+// whatever -ldflags buildFlags already carries, adding a new -ldflags
+// argument if there wasn't one.
+func MergeLdflags(buildFlags, xflags []string) []string {
+	if len(xflags) == 0 {
+		return buildFlags
+	}
+	var addition strings.Builder
+	for i, x := range xflags {
+		if i > 0 {
+			addition.WriteByte(' ')
+		}
+		fmt.Fprintf(&addition, "-X %s", x)
+	}
+
+	out := make([]string, 0, len(buildFlags)+2)
+	merged := false
+	for i := 0; i < len(buildFlags); i++ {
+		a := buildFlags[i]
+		switch {
+		case a == "-ldflags" && i+1 < len(buildFlags):
+			out = append(out, a, buildFlags[i+1]+" "+addition.String())
+			i++
+			merged = true
+		case strings.HasPrefix(a, "-ldflags="):
+			out = append(out, a+" "+addition.String())
+			merged = true
+		default:
+			out = append(out, a)
+		}
+	}
+	if !merged {
+		out = append(out, "-ldflags", addition.String())
+	}
+	return out
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package installas
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeLdflags(t *testing.T) {
+	tests := []struct {
+		name       string
+		buildFlags []string
+		xflags     []string
+		want       []string
+	}{
+		{
+			name:       "no xflags leaves buildFlags untouched",
+			buildFlags: []string{"-tags", "integration"},
+			xflags:     nil,
+			want:       []string{"-tags", "integration"},
+		},
+		{
+			name:       "no existing -ldflags adds one",
+			buildFlags: []string{"-tags", "integration"},
+			xflags:     []string{"main.version=v1.2.3"},
+			want:       []string{"-tags", "integration", "-ldflags", "-X main.version=v1.2.3"},
+		},
+		{
+			name:       "multiple xflags join with spaces",
+			buildFlags: nil,
+			xflags:     []string{"main.version=v1.2.3", "main.commit=abcdef"},
+			want:       []string{"-ldflags", "-X main.version=v1.2.3 -X main.commit=abcdef"},
+		},
+		{
+			name:       "existing -ldflags value is extended",
+			buildFlags: []string{"-ldflags", "-s -w"},
+			xflags:     []string{"main.version=v1.2.3"},
+			want:       []string{"-ldflags", "-s -w -X main.version=v1.2.3"},
+		},
+		{
+			name:       "existing -ldflags=value form is extended",
+			buildFlags: []string{"-ldflags=-s -w"},
+			xflags:     []string{"main.version=v1.2.3"},
+			want:       []string{"-ldflags=-s -w -X main.version=v1.2.3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MergeLdflags(tt.buildFlags, tt.xflags)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeLdflags(%v, %v) = %v, want %v", tt.buildFlags, tt.xflags, got, tt.want)
+			}
+		})
+	}
+}
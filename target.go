@@ -0,0 +1,81 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package installas
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/mod/semver"
+
+	"github.com/hyangah/installas/internal/fakeproxy"
+)
+
+// resolvedTarget is a target@version argument resolved against its
+// enclosing module.
+type resolvedTarget struct {
+	PackagePath   string
+	Module        string
+	ModuleRootDir string
+	Version       string
+}
+
+// resolveTarget parses a raw target@version argument, resolves it against
+// its enclosing module via `go list`, and, if the version is @pseudo or
+// @devel, synthesizes a pseudo-version from the module's VCS state. goCmd
+// is the go binary to invoke, as resolved by GoCommand; env is the
+// subprocess environment, as built by Install from Options.Env.
+func resolveTarget(ctx context.Context, goCmd string, env []string, raw string) (resolvedTarget, error) {
+	raw = strings.TrimSpace(raw)
+	targetPath, version, ok := strings.Cut(raw, "@")
+	if !ok {
+		return resolvedTarget{}, &Error{Kind: KindInvalidTarget, Err: fmt.Errorf("target %q: expected package@version or @version", raw)}
+	}
+	isPseudoRequest := version == "pseudo" || version == "devel"
+	if !isPseudoRequest && !semver.IsValid(version) {
+		return resolvedTarget{}, &Error{Kind: KindInvalidTarget, Err: fmt.Errorf("target %q: version %q is invalid", raw, version)}
+	}
+	if targetPath == "" {
+		targetPath = "."
+	}
+
+	listCmd := exec.CommandContext(ctx, goCmd, "list", "-f", `{{printf "%s\n%s\n%s" .ImportPath .Module.Path .Module.Dir -}}`, targetPath)
+	listCmd.Env = env
+	out, err := listCmd.Output()
+	if err != nil {
+		return resolvedTarget{}, &Error{Kind: KindGoListFailed, Err: fmt.Errorf("target %q: %w", raw, err)}
+	}
+	f := strings.Split(string(out), "\n")
+	if len(f) < 3 {
+		return resolvedTarget{}, &Error{Kind: KindInvalidTarget, Err: fmt.Errorf("target %q: unexpected `go list` output:\n%+v", raw, out)}
+	}
+	t := resolvedTarget{
+		PackagePath:   strings.TrimSpace(f[0]),
+		Module:        strings.TrimSpace(f[1]),
+		ModuleRootDir: strings.TrimSpace(f[2]),
+		Version:       version,
+	}
+
+	if isPseudoRequest {
+		t.Version, err = ResolvePseudoVersion(t.ModuleRootDir, t.Module)
+		if err != nil {
+			return resolvedTarget{}, &Error{Kind: KindInvalidTarget, Err: fmt.Errorf("target %q: %w", raw, err)}
+		}
+	}
+	return t, nil
+}
+
+// startProxy makes the module versions written under rootDir reachable via
+// a GOPROXY entry, either as a file:// URL or, if useHTTP is set, via an
+// in-process fakeproxy HTTP server. The returned shutdown func must be
+// called once the caller is done with the proxy.
+func startProxy(rootDir string, useHTTP bool, mods []fakeproxy.ModuleVersion) (url string, shutdown func(), err error) {
+	if !useHTTP {
+		return ProxyFileURL(rootDir), func() {}, nil
+	}
+	return fakeproxy.Serve(rootDir, mods)
+}